@@ -0,0 +1,28 @@
+"""Input CSV delimiters: sniffed from the header line, or set with --csv-delimiter."""
+import pytest
+
+from conftest import read_csv
+
+EXPECTED_OPEN = [
+    {"ORG_DISPLAY_NAME": "Acme Payments", "CRITICAL": "1", "HIGH": "1", "MEDIUM": "0", "LOW": "0"},
+    {"ORG_DISPLAY_NAME": "Acme Retail", "CRITICAL": "0", "HIGH": "1", "MEDIUM": "0", "LOW": "0"},
+]
+
+
+@pytest.mark.parametrize("fixture", ["delimiter-comma.csv", "delimiter-semicolon.csv", "delimiter-tab.csv"])
+def test_delimiter_is_sniffed(run_offline, fixture):
+    exit_code, output = run_offline([fixture])
+    assert exit_code == 0
+
+    assert read_csv(output / "summary-Open.csv") == EXPECTED_OPEN
+    # Written files always use commas, whatever the input delimiter
+    header = (output / "issues-Resolved.csv").read_text(encoding="utf-8").splitlines()[0]
+    assert header == "ORG_DISPLAY_NAME,PROJECT_NAME,ISSUE_SEVERITY,ISSUE_STATUS,PROBLEM_TITLE"
+
+
+@pytest.mark.parametrize("fixture, delimiter", [("delimiter-semicolon.csv", ";"), ("delimiter-tab.csv", "tab")])
+def test_explicit_delimiter(run_offline, fixture, delimiter):
+    exit_code, output = run_offline([fixture], "--csv-delimiter", delimiter)
+    assert exit_code == 0
+
+    assert read_csv(output / "summary-Open.csv") == EXPECTED_OPEN
@@ -0,0 +1,39 @@
+"""Failed downloads: what is left in the output folder."""
+import logging
+
+logger = logging.getLogger("test")
+
+
+def test_partial_file_is_deleted(exporter, tmp_path):
+    filepath = tmp_path / "csv_1.csv"
+    filepath.write_text("ORG_DISPLAY_NAME,ISSUE_SEVERITY\nAcme Pay", encoding="utf-8")
+
+    exporter._remove_partial_file(filepath, False, logger)
+
+    assert list(tmp_path.iterdir()) == []
+
+
+def test_keep_partial_renames_the_file_out_of_the_review(exporter, tmp_path):
+    filepath = tmp_path / "csv_1.csv"
+    filepath.write_text("ORG_DISPLAY_NAME,ISSUE_SEVERITY\nAcme Pay", encoding="utf-8")
+
+    exporter._remove_partial_file(filepath, True, logger)
+
+    assert sorted(path.name for path in tmp_path.iterdir()) == ["csv_1.csv.partial"]
+
+
+def test_unsaved_files_are_listed_as_missing(exporter, tmp_path, monkeypatch):
+    def disk_full(session, url, filepath, file_size, timeout, logger):
+        if filepath.name == "csv_2.csv":
+            raise exporter.DiskWriteError(f"cannot write {filepath.name}: No space left on device")
+        filepath.write_text("ORG_DISPLAY_NAME,ISSUE_SEVERITY\nAcme Payments,high\n", encoding="utf-8")
+
+    monkeypatch.setattr(exporter, "_download_file", disk_full)
+    config = exporter.Config()
+    config.OUTPUT_FOLDER = str(tmp_path)
+    results = [{"url": f"https://storage.example.com/result-{n}"} for n in (1, 2)] + [{"url": None}]
+
+    downloaded, _, _, missing_files = exporter._download_results(config, results, None, logger, None)
+
+    assert (downloaded, missing_files) == (1, ["csv_2.csv", "csv_3.csv"])
+    assert sorted(path.name for path in tmp_path.iterdir()) == ["csv_1.csv"]
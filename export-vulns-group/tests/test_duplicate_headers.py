@@ -0,0 +1,21 @@
+"""Repeated CSV column headers: renamed with a _N suffix instead of overwriting a column."""
+from conftest import read_csv
+
+
+def test_dedupe_fieldnames_keeps_the_first_and_suffixes_the_rest(exporter):
+    assert exporter.dedupe_fieldnames(["SCORE", "CVE", "SCORE", "SCORE"]) == (
+        ["SCORE", "CVE", "SCORE_2", "SCORE_3"], ["SCORE"]
+    )
+
+
+def test_dedupe_fieldnames_skips_names_already_in_the_header(exporter):
+    assert exporter.dedupe_fieldnames(["A", "A", "A_2"]) == (["A", "A_3", "A_2"], ["A"])
+
+
+def test_duplicate_header_keeps_both_columns(run_offline):
+    exit_code, output = run_offline(["duplicate-headers.csv"])
+    assert exit_code == 0
+
+    rows = read_csv(output / "issues-Open.csv")
+    assert [(row["SCORE"], row["SCORE_2"]) for row in rows] == [("920", "100"), ("705", "200")]
+    assert [row["SCORE"] for row in read_csv(output / "issues-Resolved.csv")] == ["430"]
@@ -0,0 +1,14 @@
+"""--gzip-output: the compressed files and the references to them."""
+import json
+
+
+def test_index_lists_the_compressed_parts(run_offline):
+    exit_code, output = run_offline(["issues.csv"], "--max-output-size", "400", "--gzip-output")
+    assert exit_code == 0
+
+    index = json.loads((output / "issues-Open-index.json").read_text(encoding="utf-8"))
+    assert len(index["parts"]) > 1
+    for part in index["parts"]:
+        assert part["file"].endswith(".csv.gz")
+        assert part["bytes"] == (output / part["file"]).stat().st_size
+    assert not list(output.glob("issues-*.csv"))
@@ -0,0 +1,58 @@
+"""--pivot severity: summary-severity-{Severity}.csv and report.json against the per-status summaries."""
+import json
+
+from conftest import read_csv
+
+
+def test_pivots_add_up_to_the_same_grand_total(run_offline):
+    exit_code, output = run_offline(["issues.csv"], "--pivot", "severity")
+    assert exit_code == 0
+
+    by_status = sum(
+        int(value)
+        for path in output.glob("summary-*.csv") if not path.name.startswith("summary-severity-")
+        for row in read_csv(path)
+        for column, value in row.items() if column != "ORG_DISPLAY_NAME"
+    )
+    by_severity = sum(
+        int(value)
+        for path in output.glob("summary-severity-*.csv")
+        for row in read_csv(path)
+        for column, value in row.items() if column != "ORG_DISPLAY_NAME"
+    )
+    assert by_status == by_severity == 10
+
+
+def test_severity_pivot_has_one_column_per_status(run_offline):
+    exit_code, output = run_offline(["issues.csv"], "--pivot", "severity")
+    assert exit_code == 0
+
+    rows = read_csv(output / "summary-severity-Critical.csv")
+    assert rows == [
+        {"ORG_DISPLAY_NAME": "Acme Payments", "Ignored": "0", "Open": "1", "Resolved": "0"},
+        {"ORG_DISPLAY_NAME": "Acme Retail", "Ignored": "1", "Open": "1", "Resolved": "0"},
+    ]
+
+
+def test_report_has_the_severity_summary(run_offline):
+    exit_code, output = run_offline(["issues.csv"], "--pivot", "severity")
+    assert exit_code == 0
+
+    report = json.loads((output / "report.json").read_text(encoding="utf-8"))
+    assert list(report["summary_by_severity"]) == ["Critical", "High", "Medium", "Low"]
+    assert report["summary_by_severity"]["Critical"] == [
+        {"ORG_DISPLAY_NAME": "Acme Payments", "Ignored": 0, "Open": 1, "Resolved": 0},
+        {"ORG_DISPLAY_NAME": "Acme Retail", "Ignored": 1, "Open": 1, "Resolved": 0},
+    ]
+    by_status = sum(row[severity] for rows in report["summary"].values() for row in rows for severity in
+                    ("CRITICAL", "HIGH", "MEDIUM", "LOW"))
+    by_severity = sum(count for rows in report["summary_by_severity"].values() for row in rows
+                      for column, count in row.items() if column != "ORG_DISPLAY_NAME")
+    assert by_status == by_severity == 10
+
+
+def test_report_has_no_severity_summary_by_default(run_offline):
+    exit_code, output = run_offline(["issues.csv"])
+    assert exit_code == 0
+
+    assert "summary_by_severity" not in json.loads((output / "report.json").read_text(encoding="utf-8"))
@@ -0,0 +1,32 @@
+"""report.json is the same on every run over the same data, apart from generated_at."""
+import json
+
+OPTIONS = ["--top", "3", "--top-problems", "3", "--per-project-average", "--score-buckets", "0,400,700,900"]
+
+
+def read_report(output):
+    report = json.loads((output / "report.json").read_text(encoding="utf-8"))
+    report.pop("generated_at")
+    return report
+
+
+def test_repeated_runs_write_the_same_report(run_offline):
+    reports = []
+    for _ in range(3):
+        exit_code, output = run_offline(["issues.csv"], *OPTIONS)
+        assert exit_code == 0
+        reports.append(read_report(output))
+
+    assert reports[0] == reports[1] == reports[2]
+    # Same key order too, so a plain diff of the files is clean
+    assert [json.dumps(report) for report in reports[1:]] == [json.dumps(reports[0])] * 2
+
+
+def test_row_order_does_not_change_the_report(run_offline):
+    # issues-reversed.csv holds the rows of issues.csv in reverse order
+    exit_code, output = run_offline(["issues.csv"], *OPTIONS)
+    assert exit_code == 0
+    exit_code, reversed_output = run_offline(["issues-reversed.csv"], *OPTIONS)
+    assert exit_code == 0
+
+    assert json.dumps(read_report(reversed_output)) == json.dumps(read_report(output))
@@ -0,0 +1,59 @@
+"""run(): the Python entry point returning the report and raising on errors."""
+import json
+import logging
+import shutil
+
+import pytest
+
+from conftest import FIXTURES
+
+
+def input_dir(tmp_path):
+    folder = tmp_path / "in"
+    folder.mkdir()
+    shutil.copyfile(FIXTURES / "issues.csv", folder / "issues.csv")
+    return folder
+
+
+def options(exporter, *args):
+    config = exporter.Config()
+    config.load(list(args))
+    return config
+
+
+def test_run_returns_the_written_report(exporter, tmp_path):
+    output = tmp_path / "out"
+
+    report = exporter.run(options(exporter, "--input-dir", str(input_dir(tmp_path)), "--output-folder", str(output)))
+
+    assert report == json.loads((output / "report.json").read_text(encoding="utf-8"))
+    assert report["total_rows"] == 10
+
+
+def test_run_raises_with_the_exit_code_and_the_report(exporter, tmp_path):
+    config = options(
+        exporter, "--input-dir", str(input_dir(tmp_path)), "--output-folder", str(tmp_path / "out"),
+        "--min-expected-rows", "11",
+    )
+
+    with pytest.raises(exporter.RunError, match="min-expected-rows") as excinfo:
+        exporter.run(config)
+
+    assert excinfo.value.exit_code == 3
+    assert excinfo.value.report["processed_rows"] == 10
+
+
+def test_nothing_carries_over_between_calls(exporter, tmp_path):
+    folder = input_dir(tmp_path)
+    report = exporter.run(options(exporter, "--input-dir", str(folder), "--output-folder", str(tmp_path / "first")))
+    assert report is not None
+
+    # Invalid options raise before anything runs
+    with pytest.raises(ValueError):
+        exporter.run(options(exporter, "--input-dir", str(tmp_path / "missing"), "--output-folder", str(tmp_path / "second")))
+    # The log file of a call is closed when it returns
+    assert logging.getLogger("snyk-export-vulns").handlers == []
+    # Neither does a SIGTERM received during an earlier call stop the next one
+    exporter._shutdown.set()
+    report = exporter.run(options(exporter, "--input-dir", str(folder), "--output-folder", str(tmp_path / "third")))
+    assert not report["incomplete"]
@@ -0,0 +1,82 @@
+"""Export results delivered as zip archives: extraction, --max-download-bytes and --resume."""
+import logging
+import shutil
+import zipfile
+
+import pytest
+
+from conftest import FIXTURES, read_csv
+
+logger = logging.getLogger("test")
+
+
+@pytest.fixture
+def downloaded_zip(tmp_path):
+    """result-two-csv.zip as downloaded result 1 (csv_1.csv); it holds two CSV entries and a README."""
+    filepath = tmp_path / "csv_1.csv"
+    shutil.copyfile(FIXTURES / "result-two-csv.zip", filepath)
+    return filepath
+
+
+def test_zip_entries_are_extracted(exporter, downloaded_zip):
+    archive_size = downloaded_zip.stat().st_size
+
+    extracted = exporter.extract_zip_result(downloaded_zip, 1, 0, logger)
+
+    assert extracted == ["csv_1_1.csv", "csv_1_2.csv"]
+    assert not downloaded_zip.exists()
+    assert len(read_csv(downloaded_zip.with_name("csv_1_1.csv"))) == 2
+    assert read_csv(downloaded_zip.with_name("csv_1_2.csv"))[0]["ORG_DISPLAY_NAME"] == "Acme Retail"
+    assert exporter.resumable_zip_entries(downloaded_zip, archive_size) == extracted
+
+
+def test_resume_downloads_again_when_an_entry_changed(exporter, downloaded_zip):
+    archive_size = downloaded_zip.stat().st_size
+    exporter.extract_zip_result(downloaded_zip, 1, 0, logger)
+
+    assert exporter.resumable_zip_entries(downloaded_zip, archive_size + 1) is None
+    with open(downloaded_zip.with_name("csv_1_2.csv"), "a", encoding="utf-8") as f:
+        f.write("Acme Retail,checkout,high,Open\n")
+    assert exporter.resumable_zip_entries(downloaded_zip, archive_size) is None
+
+
+def test_plain_csv_is_not_extracted(exporter, tmp_path):
+    filepath = tmp_path / "csv_1.csv"
+    shutil.copyfile(FIXTURES / "issues.csv", filepath)
+
+    assert exporter.extract_zip_result(filepath, 1, 0, logger) is None
+    assert filepath.exists()
+
+
+def test_zip_larger_than_max_bytes_is_not_extracted(exporter, downloaded_zip):
+    with pytest.raises(RuntimeError, match="max-download-bytes"):
+        exporter.extract_zip_result(downloaded_zip, 1, 100, logger)
+    assert sorted(path.name for path in downloaded_zip.parent.iterdir()) == ["csv_1.csv"]
+
+    assert exporter.extract_zip_result(downloaded_zip, 1, 10_000, logger) == ["csv_1_1.csv", "csv_1_2.csv"]
+
+
+def test_zip_unpacking_to_more_than_the_ratio_is_not_extracted(exporter, tmp_path):
+    filepath = tmp_path / "csv_1.csv"
+    with zipfile.ZipFile(filepath, "w", zipfile.ZIP_DEFLATED) as archive:
+        archive.writestr("bomb.csv", "ORG_DISPLAY_NAME\n" + "a\n" * 1_000_000)
+
+    with pytest.raises(RuntimeError, match="times the archive size"):
+        exporter.extract_zip_result(filepath, 1, 0, logger)
+    assert sorted(path.name for path in tmp_path.iterdir()) == ["csv_1.csv"]
+
+
+def test_resume_unpacks_a_zip_the_interrupted_run_did_not(exporter, downloaded_zip):
+    # Killed between the download and extract_zip_result: csv_1.csv is the archive, with the right size
+    config = exporter.Config()
+    config.OUTPUT_FOLDER = str(downloaded_zip.parent)
+    config.RESUME = True
+    results = [{"url": "https://storage.example.com/result-1", "file_size": downloaded_zip.stat().st_size}]
+
+    downloaded, _, resumed_files, missing_files = exporter._download_results(config, results, None, logger, None)
+
+    assert (downloaded, resumed_files, missing_files) == (1, ["csv_1.csv"], [])
+    assert sorted(path.name for path in downloaded_zip.parent.iterdir()) == [
+        "csv_1.zip.json", "csv_1_1.csv", "csv_1_2.csv"
+    ]
+